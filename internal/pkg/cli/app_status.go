@@ -4,11 +4,20 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/config"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe/status"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/color"
 	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
 	"github.com/spf13/cobra"
@@ -19,6 +28,40 @@ const (
 	appStatusProjectNameHelpPrompt = "A project groups all of your applications together."
 	appStatusAppNamePrompt         = "Which application's status would you like to show?"
 	appStatusAppNameHelpPrompt     = "Displays the service's, tasks and CloudWatch alarms status."
+
+	watchFlag                = "watch"
+	watchFlagDescription     = "Optional. Keep watching for status changes and re-render as they arrive."
+	intervalFlag             = "interval"
+	intervalFlagDescription  = "Optional. How often to poll for status changes in --watch mode."
+	onceFlag                 = "once"
+	onceFlagDescription      = "Optional. Show the status once and exit, overriding --watch. This is the default behavior."
+	formatFlag               = "format"
+	formatFlagDescription    = "Optional. Output format: human, json, yaml, tsv, template, or prometheus. Overrides --json."
+	templateFlag             = "template"
+	templateFlagDescription  = "Optional. Go text/template string used when --format=template."
+	listenFlag               = "listen"
+	listenFlagDescription    = "Optional. Serve --format=prometheus metrics over HTTP at this address (e.g. :9100), re-describing on each scrape with a short cache TTL, instead of printing once."
+	allFlag                  = "all"
+	allFlagDescription       = "Optional. Show status for every deployed service/environment pair."
+	svcFilterFlag            = "svc"
+	svcFilterFlagDescription = "Optional. Restrict --all to these services. Can be specified multiple times."
+	noInputFlag              = "no-input"
+	noInputFlagDescription   = "Optional. Disable interactive prompts; --app and (unless --all) --svc/--env must be set. Also honors CI=true."
+
+	defaultWatchInterval = 10 * time.Second
+	allWorkerPoolSize    = 8
+	metricsCacheTTL      = 5 * time.Second
+
+	// Exit codes for using `app status` as a scriptable health gate: 0 means healthy, 2 means
+	// degraded (e.g. an alarm in INSUFFICIENT_DATA, or the running task count hasn't caught up
+	// to desired yet, as during a routine rolling deploy), 3 means failed (an alarm is in ALARM
+	// or a task stopped), 4 means the application stack doesn't exist, and 5 means the AWS API
+	// call itself failed.
+	exitCodeHealthy       = 0
+	exitCodeDegraded      = 2
+	exitCodeUnhealthy     = 3
+	exitCodeStackNotFound = 4
+	exitCodeAWSError      = 5
 )
 
 type appStatusVars struct {
@@ -26,20 +69,50 @@ type appStatusVars struct {
 	shouldOutputJSON bool
 	svcName          string
 	envName          string
+	watch            bool
+	once             bool
+	interval         time.Duration
+	format           string
+	template         string
+	all              bool
+	envFilters       []string // bound to the repeatable --env flag
+	svcFilters       []string // bound to the repeatable --svc flag
+	listenAddr       string
+	noInput          bool
+}
+
+// statusDescriber describes the status of a deployed application, optionally streaming updates.
+type statusDescriber interface {
+	Describe() (*describe.AppStatus, error)
+	DescribeContext(ctx context.Context) (*describe.AppStatus, error)
+	StreamStatus(ctx context.Context, interval time.Duration) (<-chan describe.AppStatus, error)
 }
 
 type appStatusOpts struct {
 	appStatusVars
 
-	w                   io.Writer
-	store               store
-	appDescriber        serviceArnGetter
-	statusDescriber     statusDescriber
-	initAppDescriber    func(*appStatusOpts, string, string) error
-	initStatusDescriber func(*appStatusOpts) error
+	w                      io.Writer
+	store                  store
+	appDescriber           serviceArnGetter
+	statusDescriber        statusDescriber
+	initAppDescriber       func(*appStatusOpts, string, string) error
+	initStatusDescriber    func(*appStatusOpts) error
+	initStatusDescriberFor func(o *appStatusOpts, svcName, envName string) (statusDescriber, error)
+
+	// lastStatus is the AppStatus resolved by Execute, used to compute the process exit code.
+	lastStatus *describe.AppStatus
 }
 
 func newAppStatusOpts(vars appStatusVars) (*appStatusOpts, error) {
+	// --env/--svc are bound as repeatable flags so --all can use them as filters; a single
+	// value also doubles as the singular selection for a non-`--all` describe.
+	if vars.envName == "" && len(vars.envFilters) == 1 {
+		vars.envName = vars.envFilters[0]
+	}
+	if vars.svcName == "" && len(vars.svcFilters) == 1 {
+		vars.svcName = vars.svcFilters[0]
+	}
+
 	ssmStore, err := config.NewStore()
 	if err != nil {
 		return nil, fmt.Errorf("connect to environment datastore: %w", err)
@@ -65,6 +138,13 @@ func newAppStatusOpts(vars appStatusVars) (*appStatusOpts, error) {
 			o.statusDescriber = d
 			return nil
 		},
+		initStatusDescriberFor: func(o *appStatusOpts, svcName, envName string) (statusDescriber, error) {
+			d, err := describe.NewAppStatus(o.AppName(), envName, svcName)
+			if err != nil {
+				return nil, fmt.Errorf("creating status describer for application %s in project %s: %w", svcName, o.AppName(), err)
+			}
+			return d, nil
+		},
 	}, nil
 }
 
@@ -90,35 +170,384 @@ func (o *appStatusOpts) Validate() error {
 
 // Ask asks for fields that are required but not passed in.
 func (o *appStatusOpts) Ask() error {
+	if o.isNonInteractive() {
+		return o.validateNonInteractiveInputs()
+	}
 	if err := o.askProject(); err != nil {
 		return err
 	}
+	if o.all {
+		// --all discovers every deployed (svc, env) pair itself; it doesn't need a single
+		// selection from the user.
+		return nil
+	}
 	return o.askAppEnvName()
 }
 
+// isNonInteractive reports whether prompts should be disabled, either because --no-input was
+// passed or because the CI environment variable is set to true.
+func (o *appStatusOpts) isNonInteractive() bool {
+	return o.noInput || strings.EqualFold(os.Getenv("CI"), "true")
+}
+
+// validateNonInteractiveInputs fails fast instead of prompting when running non-interactively.
+func (o *appStatusOpts) validateNonInteractiveInputs() error {
+	if o.AppName() == "" {
+		return fmt.Errorf("--%s must be specified when --%s is set", appFlag, noInputFlag)
+	}
+	if o.all {
+		return nil
+	}
+	if o.svcName == "" {
+		return fmt.Errorf("--%s must be specified when --%s is set", svcFilterFlag, noInputFlag)
+	}
+	if o.envName == "" {
+		return fmt.Errorf("--%s must be specified when --%s is set", envFlag, noInputFlag)
+	}
+	return nil
+}
+
 // Execute shows the applications through the prompt.
 func (o *appStatusOpts) Execute() error {
-	err := o.initStatusDescriber(o)
-	if err != nil {
+	if o.all {
+		return o.executeAll()
+	}
+	if err := o.initStatusDescriber(o); err != nil {
 		return err
 	}
+	if o.listenAddr != "" {
+		return o.serveMetrics()
+	}
+	if o.watch && !o.once {
+		return o.executeWatch()
+	}
 	appStatus, err := o.statusDescriber.Describe()
 	if err != nil {
 		return fmt.Errorf("describe status of application %s: %w", o.svcName, err)
 	}
-	if o.shouldOutputJSON {
-		data, err := appStatus.JSONString()
+	o.lastStatus = appStatus
+	return o.render(appStatus)
+}
+
+// exitCode derives the process exit code to use for a resolved run of `app status`, per the
+// contract documented on BuildAppStatusCmd: 0 healthy, 2 degraded, 3 unhealthy, 4 stack not
+// found, 5 AWS API error. It's opt-in: it only applies when --no-input (or CI=true) was used,
+// so that plain `app status` invocations keep their existing 0/1 cobra exit behavior rather
+// than failing a script that merely checked the describe itself succeeded. It also only
+// applies to the single (svc, env) describe path; --all, --watch, and --listen runs exit 0/1
+// the usual cobra way since they don't resolve to one snapshot.
+func (o *appStatusOpts) exitCode(runErr error) (code int, ok bool) {
+	if !o.isNonInteractive() || o.all || o.listenAddr != "" || (o.watch && !o.once) {
+		return 0, false
+	}
+	if runErr != nil {
+		if describe.IsStackNotExistsErr(runErr) {
+			return exitCodeStackNotFound, true
+		}
+		return exitCodeAWSError, true
+	}
+	if o.lastStatus == nil {
+		return 0, false
+	}
+	switch o.lastStatus.Health() {
+	case describe.HealthStateHealthy:
+		return exitCodeHealthy, true
+	case describe.HealthStateDegraded:
+		return exitCodeDegraded, true
+	default:
+		return exitCodeUnhealthy, true
+	}
+}
+
+// serveMetrics exposes the application's status as Prometheus/OpenMetrics text on
+// --listen, re-describing on each scrape no more often than metricsCacheTTL.
+func (o *appStatusOpts) serveMetrics() error {
+	var mu sync.Mutex
+	var cached *describe.AppStatus
+	var cachedAt time.Time
+
+	renderer, err := status.New(status.Prometheus, "")
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if cached == nil || time.Since(cachedAt) > metricsCacheTTL {
+			appStatus, err := o.statusDescriber.Describe()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			cached, cachedAt = appStatus, time.Now()
+		}
+		if err := renderer.Render(w, cached); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	log.Infof("Serving metrics at http://%s/metrics\n", o.listenAddr)
+	return http.ListenAndServe(o.listenAddr, mux)
+}
+
+// appStatusResult is the outcome of describing the status of a single (svc, env) pair.
+type appStatusResult struct {
+	Svc    string              `json:"svc"`
+	Env    string              `json:"env"`
+	Status *describe.AppStatus `json:"status,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// appStatusSummary tallies the health of every pair reported in an --all run.
+type appStatusSummary struct {
+	Healthy  int `json:"healthy"`
+	Degraded int `json:"degraded"`
+	Failed   int `json:"failed"`
+}
+
+// appStatusReport is the combined output of an --all run.
+type appStatusReport struct {
+	Results []appStatusResult `json:"results"`
+	Summary appStatusSummary  `json:"summary"`
+}
+
+// executeAll concurrently describes every deployed (svc, env) pair discovered for the
+// project, optionally restricted by the repeatable --env/--svc flags, and renders a combined
+// report. Individual pair failures are collected and shown inline rather than aborting the
+// whole run. Ctrl-C cancels ctx, stopping in-flight and queued workers early.
+func (o *appStatusOpts) executeAll() error {
+	pairs, err := o.discoverAllPairs()
+	if err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return fmt.Errorf("no deployed apps found in project %s", color.HighlightUserInput(o.AppName()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	type pairJob struct {
+		index int
+		pair  svcEnv
+	}
+	type indexedResult struct {
+		index int
+		res   appStatusResult
+	}
+	pairCh := make(chan pairJob)
+	// Buffered so a worker's send never blocks if ctx is cancelled before the result loop
+	// below reads it, letting every worker goroutine exit promptly.
+	resultCh := make(chan indexedResult, len(pairs))
+
+	poolSize := allWorkerPoolSize
+	if poolSize > len(pairs) {
+		poolSize = len(pairs)
+	}
+	for w := 0; w < poolSize; w++ {
+		go func() {
+			for job := range pairCh {
+				resultCh <- indexedResult{index: job.index, res: o.describePair(ctx, job.pair)}
+			}
+		}()
+	}
+	go func() {
+		defer close(pairCh)
+		for i, pair := range pairs {
+			select {
+			case pairCh <- pairJob{i, pair}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]appStatusResult, len(pairs))
+	pending := make(map[int]svcEnv, len(pairs))
+	for i, pair := range pairs {
+		pending[i] = pair
+	}
+collect:
+	for range pairs {
+		select {
+		case r := <-resultCh:
+			results[r.index] = r.res
+			delete(pending, r.index)
+		case <-ctx.Done():
+			break collect
+		}
+	}
+	for i, pair := range pending {
+		results[i] = appStatusResult{Svc: pair.svcName, Env: pair.envName, Error: ctx.Err().Error()}
+	}
+
+	report := appStatusReport{Results: results}
+	for _, r := range results {
+		switch {
+		case r.Status == nil:
+			report.Summary.Failed++
+		case r.Status.Health() == describe.HealthStateHealthy:
+			report.Summary.Healthy++
+		case r.Status.Health() == describe.HealthStateDegraded:
+			report.Summary.Degraded++
+		default:
+			report.Summary.Failed++
+		}
+	}
+
+	if o.resolvedFormat() == status.JSON {
+		b, err := json.Marshal(report.Results)
 		if err != nil {
-			return err
+			return fmt.Errorf("marshal status report: %w", err)
+		}
+		fmt.Fprintf(o.w, "%s\n", b)
+		return nil
+	}
+	o.renderAllHuman(report)
+	return nil
+}
+
+func (o *appStatusOpts) describePair(ctx context.Context, pair svcEnv) appStatusResult {
+	res := appStatusResult{Svc: pair.svcName, Env: pair.envName}
+	d, err := o.initStatusDescriberFor(o, pair.svcName, pair.envName)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	appStatus, err := d.DescribeContext(ctx)
+	if err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Status = appStatus
+	return res
+}
+
+// discoverAllPairs finds every deployed (svc, env) pair for the project, honoring the
+// repeatable --svc/--env flags as optional allow-lists.
+func (o *appStatusOpts) discoverAllPairs() ([]svcEnv, error) {
+	svcNames := o.svcFilters
+	if len(svcNames) == 0 {
+		var err error
+		svcNames, err = o.retrieveAllAppNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+	envNames := o.envFilters
+	if len(envNames) == 0 {
+		var err error
+		envNames, err = o.retrieveAllEnvNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var pairs []svcEnv
+	for _, svcName := range svcNames {
+		for _, envName := range envNames {
+			if err := o.initAppDescriber(o, envName, svcName); err != nil {
+				return nil, err
+			}
+			if _, err := o.appDescriber.GetServiceArn(); err != nil {
+				if describe.IsStackNotExistsErr(err) {
+					continue
+				}
+				return nil, fmt.Errorf("check if app %s is deployed in env %s: %w", svcName, envName, err)
+			}
+			pairs = append(pairs, svcEnv{svcName: svcName, envName: envName})
 		}
-		fmt.Fprintf(o.w, data)
-	} else {
-		fmt.Fprintf(o.w, appStatus.HumanString())
 	}
+	return pairs, nil
+}
 
+func (o *appStatusOpts) renderAllHuman(report appStatusReport) {
+	byEnv := make(map[string][]appStatusResult)
+	var envOrder []string
+	for _, r := range report.Results {
+		if _, ok := byEnv[r.Env]; !ok {
+			envOrder = append(envOrder, r.Env)
+		}
+		byEnv[r.Env] = append(byEnv[r.Env], r)
+	}
+	for _, env := range envOrder {
+		fmt.Fprintf(o.w, "Environment: %s\n", env)
+		for _, r := range byEnv[env] {
+			if r.Error != "" {
+				fmt.Fprintf(o.w, "  %s: error: %s\n", r.Svc, r.Error)
+				continue
+			}
+			fmt.Fprintf(o.w, "  %s: %s\n", r.Svc, r.Status.Health())
+		}
+	}
+	fmt.Fprintf(o.w, "\nSummary: %d healthy, %d degraded, %d failed\n", report.Summary.Healthy, report.Summary.Degraded, report.Summary.Failed)
+}
+
+// executeWatch streams status updates until the user interrupts with Ctrl-C, re-rendering
+// the table in place for human output or emitting one NDJSON record per change for --json.
+func (o *appStatusOpts) executeWatch() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	interval := o.interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	updates, err := o.statusDescriber.StreamStatus(ctx, interval)
+	if err != nil {
+		return fmt.Errorf("stream status of application %s: %w", o.svcName, err)
+	}
+	for update := range updates {
+		update := update
+		if o.resolvedFormat() == status.Human {
+			// Clear the screen before re-rendering so the table appears to update in-place.
+			fmt.Fprint(o.w, "\033[H\033[2J")
+		}
+		if err := o.render(&update); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// resolvedFormat returns the output format to render with, honoring --json as an alias for
+// --format=json when --format was not explicitly set.
+func (o *appStatusOpts) resolvedFormat() string {
+	if o.format == "" && o.shouldOutputJSON {
+		return status.JSON
+	}
+	if o.format == "" {
+		return status.Human
+	}
+	return o.format
+}
+
+func (o *appStatusOpts) render(appStatus *describe.AppStatus) error {
+	format := o.resolvedFormat()
+	renderer, err := status.New(format, o.template)
+	if err != nil {
+		return fmt.Errorf("create %q renderer: %w", format, err)
+	}
+	return renderer.Render(o.w, appStatus)
+}
+
 func (o *appStatusOpts) askProject() error {
 	if o.AppName() != "" {
 		return nil
@@ -263,7 +692,16 @@ func BuildAppStatusCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Shows status of a deployed application.",
-		Long:  "Shows status of a deployed application, including service status, task status, and related CloudWatch alarms.",
+		Long: `Shows status of a deployed application, including service status, task status, and related CloudWatch alarms.
+
+Exit codes: with --no-input (or CI=true) against a single service/environment, the process
+exits 0 if the service is healthy, 2 if it's degraded (e.g. an alarm is in INSUFFICIENT_DATA,
+or the running task count hasn't caught up to desired yet, as during a routine rolling
+deploy), 3 if it's unhealthy (an alarm is in ALARM or a task stopped), 4 if the application
+stack doesn't exist, and 5 if the AWS API call itself failed. This makes the command usable as a
+health gate in deployment pipelines without changing the default interactive behavior: without
+--no-input (and with CI unset), the command always exits 0/1 the usual cobra way, as it always has. --all, --watch,
+and --listen runs likewise exit 0/1 since they don't resolve to a single snapshot.`,
 
 		Example: `
   Shows status of the deployed application "my-app"
@@ -279,13 +717,36 @@ func BuildAppStatusCmd() *cobra.Command {
 			if err := opts.Ask(); err != nil {
 				return err
 			}
-			return opts.Execute()
+			runErr := opts.Execute()
+			if code, ok := opts.exitCode(runErr); ok {
+				// exitCode is opt-in (see its doc comment), so this only fires for
+				// --no-input (or CI=true) runs: print runErr ourselves since os.Exit never lets
+				// cobra's own error reporting run.
+				if runErr != nil {
+					fmt.Fprintln(os.Stderr, runErr)
+				}
+				os.Exit(code)
+			}
+			return runErr
 		}),
 	}
 	// The flags bound by viper are available to all sub-commands through viper.GetString({flagName})
 	cmd.Flags().StringVarP(&vars.appName, nameFlag, nameFlagShort, "", svcFlagDescription)
-	cmd.Flags().StringVarP(&vars.envName, envFlag, envFlagShort, "", envFlagDescription)
+	// --env is repeatable: a single value selects the environment for a single-pair describe,
+	// multiple values restrict --all to those environments.
+	cmd.Flags().StringArrayVarP(&vars.envFilters, envFlag, envFlagShort, nil, envFlagDescription)
 	cmd.Flags().BoolVar(&vars.shouldOutputJSON, jsonFlag, false, jsonFlagDescription)
 	cmd.Flags().StringVarP(&vars.appName, appFlag, appFlagShort, "", appFlagDescription)
+	cmd.Flags().BoolVar(&vars.watch, watchFlag, false, watchFlagDescription)
+	cmd.Flags().BoolVar(&vars.once, onceFlag, false, onceFlagDescription)
+	cmd.Flags().DurationVar(&vars.interval, intervalFlag, defaultWatchInterval, intervalFlagDescription)
+	cmd.Flags().StringVar(&vars.format, formatFlag, "", formatFlagDescription)
+	cmd.Flags().StringVar(&vars.template, templateFlag, "", templateFlagDescription)
+	cmd.Flags().BoolVar(&vars.all, allFlag, false, allFlagDescription)
+	// --svc is repeatable for the same reason --env is: one value for a single-pair describe,
+	// several to restrict --all.
+	cmd.Flags().StringArrayVar(&vars.svcFilters, svcFilterFlag, nil, svcFilterFlagDescription)
+	cmd.Flags().StringVar(&vars.listenAddr, listenFlag, "", listenFlagDescription)
+	cmd.Flags().BoolVar(&vars.noInput, noInputFlag, false, noInputFlagDescription)
 	return cmd
 }
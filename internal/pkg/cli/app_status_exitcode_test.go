@@ -0,0 +1,113 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppStatusOpts_ExitCode(t *testing.T) {
+	testCases := map[string]struct {
+		opts     appStatusOpts
+		runErr   error
+		wantCode int
+		wantOk   bool
+	}{
+		"not opted in: interactive run never sets an exit code": {
+			opts:   appStatusOpts{appStatusVars: appStatusVars{noInput: false}},
+			wantOk: false,
+		},
+		"--all is never gated, even with --no-input": {
+			opts:   appStatusOpts{appStatusVars: appStatusVars{noInput: true, all: true}},
+			wantOk: false,
+		},
+		"--listen is never gated": {
+			opts:   appStatusOpts{appStatusVars: appStatusVars{noInput: true, listenAddr: ":9100"}},
+			wantOk: false,
+		},
+		"--watch without --once is never gated": {
+			opts:   appStatusOpts{appStatusVars: appStatusVars{noInput: true, watch: true}},
+			wantOk: false,
+		},
+		"--watch with --once is gated like a single describe": {
+			opts: appStatusOpts{
+				appStatusVars: appStatusVars{noInput: true, watch: true, once: true},
+				lastStatus:    healthyStatus(),
+			},
+			wantCode: exitCodeHealthy,
+			wantOk:   true,
+		},
+		"stack not found maps to exitCodeStackNotFound": {
+			opts:     appStatusOpts{appStatusVars: appStatusVars{noInput: true}},
+			runErr:   describe.ErrStackNotExists,
+			wantCode: exitCodeStackNotFound,
+			wantOk:   true,
+		},
+		"generic describe error maps to exitCodeAWSError": {
+			opts:     appStatusOpts{appStatusVars: appStatusVars{noInput: true}},
+			runErr:   errors.New("some AWS API call failed"),
+			wantCode: exitCodeAWSError,
+			wantOk:   true,
+		},
+		"no error and no resolved status: not gated": {
+			opts:   appStatusOpts{appStatusVars: appStatusVars{noInput: true}},
+			wantOk: false,
+		},
+		"healthy status maps to exitCodeHealthy": {
+			opts: appStatusOpts{
+				appStatusVars: appStatusVars{noInput: true},
+				lastStatus:    healthyStatus(),
+			},
+			wantCode: exitCodeHealthy,
+			wantOk:   true,
+		},
+		"degraded status maps to exitCodeDegraded": {
+			opts: appStatusOpts{
+				appStatusVars: appStatusVars{noInput: true},
+				lastStatus:    degradedStatus(),
+			},
+			wantCode: exitCodeDegraded,
+			wantOk:   true,
+		},
+		"failed status maps to exitCodeUnhealthy": {
+			opts: appStatusOpts{
+				appStatusVars: appStatusVars{noInput: true},
+				lastStatus:    unhealthyStatus(),
+			},
+			wantCode: exitCodeUnhealthy,
+			wantOk:   true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			code, ok := tc.opts.exitCode(tc.runErr)
+			require.Equal(t, tc.wantOk, ok)
+			if tc.wantOk {
+				require.Equal(t, tc.wantCode, code)
+			}
+		})
+	}
+}
+
+func healthyStatus() *describe.AppStatus {
+	return &describe.AppStatus{}
+}
+
+func degradedStatus() *describe.AppStatus {
+	return &describe.AppStatus{
+		Alarms: []cloudwatch.MetricAlarm{{StateValue: aws.String("INSUFFICIENT_DATA")}},
+	}
+}
+
+func unhealthyStatus() *describe.AppStatus {
+	return &describe.AppStatus{
+		Alarms: []cloudwatch.MetricAlarm{{StateValue: aws.String("ALARM")}},
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+// ecsClient adapts *ecs.ECS to the ecsServiceGetter interface expected by ecsServiceSource.
+type ecsClient struct {
+	client *ecs.ECS
+}
+
+// Service describes the named ECS service.
+func (c *ecsClient) Service(cluster, service string) (*ecs.Service, error) {
+	out, err := c.client.DescribeServices(&ecs.DescribeServicesInput{
+		Cluster:  aws.String(cluster),
+		Services: aws.StringSlice([]string{service}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe ECS service %s: %w", service, err)
+	}
+	if len(out.Services) == 0 {
+		// The service isn't deployed: report it as ErrStackNotExists so callers can tell it apart
+		// from an API failure.
+		return nil, fmt.Errorf("service %s not found in cluster %s: %w", service, cluster, ErrStackNotExists)
+	}
+	return out.Services[0], nil
+}
+
+// ServiceTasks lists and describes every task currently running the named ECS service.
+func (c *ecsClient) ServiceTasks(cluster, service string) ([]*ecs.Task, error) {
+	listOut, err := c.client.ListTasks(&ecs.ListTasksInput{
+		Cluster:     aws.String(cluster),
+		ServiceName: aws.String(service),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list tasks for service %s: %w", service, err)
+	}
+	if len(listOut.TaskArns) == 0 {
+		return nil, nil
+	}
+	descOut, err := c.client.DescribeTasks(&ecs.DescribeTasksInput{
+		Cluster: aws.String(cluster),
+		Tasks:   listOut.TaskArns,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe tasks for service %s: %w", service, err)
+	}
+	return descOut.Tasks, nil
+}
+
+// cloudwatchClient adapts *cloudwatch.CloudWatch (plus the tagging API, since CloudWatch alarms
+// can't be filtered by tag directly) to the alarmGetter and containerInsightsGetter interfaces.
+type cloudwatchClient struct {
+	client   *cloudwatch.CloudWatch
+	rgClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
+}
+
+// AlarmsWithTags returns every CloudWatch alarm tagged with all of the given key/value pairs.
+func (c *cloudwatchClient) AlarmsWithTags(tags map[string]string) ([]cloudwatch.MetricAlarm, error) {
+	tagFilters := make([]*resourcegroupstaggingapi.TagFilter, 0, len(tags))
+	for k, v := range tags {
+		tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{
+			Key:    aws.String(k),
+			Values: aws.StringSlice([]string{v}),
+		})
+	}
+	out, err := c.rgClient.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{"cloudwatch:alarm"}),
+		TagFilters:          tagFilters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find tagged cloudwatch alarms: %w", err)
+	}
+	if len(out.ResourceTagMappingList) == 0 {
+		return nil, nil
+	}
+	names := make([]*string, 0, len(out.ResourceTagMappingList))
+	for _, r := range out.ResourceTagMappingList {
+		parsed, err := arn.Parse(aws.StringValue(r.ResourceARN))
+		if err != nil {
+			continue
+		}
+		// The alarm ARN's resource segment is "alarm:<name>".
+		if strings.HasPrefix(parsed.Resource, "alarm:") {
+			names = append(names, aws.String(strings.TrimPrefix(parsed.Resource, "alarm:")))
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	descOut, err := c.client.DescribeAlarms(&cloudwatch.DescribeAlarmsInput{AlarmNames: names})
+	if err != nil {
+		return nil, fmt.Errorf("describe cloudwatch alarms: %w", err)
+	}
+	alarms := make([]cloudwatch.MetricAlarm, 0, len(descOut.MetricAlarms))
+	for _, a := range descOut.MetricAlarms {
+		alarms = append(alarms, *a)
+	}
+	return alarms, nil
+}
+
+// ContainerInsights returns the CPU/memory utilization p50/p95 for the given cluster/service
+// over window, as reported by CloudWatch Container Insights.
+func (c *cloudwatchClient) ContainerInsights(cluster, service string, window time.Duration) (*ContainerInsights, error) {
+	end := time.Now()
+	start := end.Add(-window)
+	dims := []*cloudwatch.Dimension{
+		{Name: aws.String("ClusterName"), Value: aws.String(cluster)},
+		{Name: aws.String("ServiceName"), Value: aws.String(service)},
+	}
+	period := aws.Int64(int64(window.Seconds()))
+	queries := []*cloudwatch.MetricDataQuery{
+		containerInsightsQuery("cpuP50", "CpuUtilized", "p50", period, dims),
+		containerInsightsQuery("cpuP95", "CpuUtilized", "p95", period, dims),
+		containerInsightsQuery("memP50", "MemoryUtilized", "p50", period, dims),
+		containerInsightsQuery("memP95", "MemoryUtilized", "p95", period, dims),
+	}
+	out, err := c.client.GetMetricData(&cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+		MetricDataQueries: queries,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get container insights metrics for %s: %w", service, err)
+	}
+	values := make(map[string]float64, len(out.MetricDataResults))
+	for _, r := range out.MetricDataResults {
+		if len(r.Values) == 0 {
+			continue
+		}
+		values[aws.StringValue(r.Id)] = aws.Float64Value(r.Values[0])
+	}
+	if len(values) == 0 {
+		// Container Insights isn't enabled for this cluster.
+		return nil, nil
+	}
+	return &ContainerInsights{
+		CPUP50:    values["cpuP50"],
+		CPUP95:    values["cpuP95"],
+		MemoryP50: values["memP50"],
+		MemoryP95: values["memP95"],
+	}, nil
+}
+
+func containerInsightsQuery(id, metricName, stat string, period *int64, dims []*cloudwatch.Dimension) *cloudwatch.MetricDataQuery {
+	return &cloudwatch.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cloudwatch.MetricStat{
+			Metric: &cloudwatch.Metric{
+				Namespace:  aws.String("ECS/ContainerInsights"),
+				MetricName: aws.String(metricName),
+				Dimensions: dims,
+			},
+			Period: period,
+			Stat:   aws.String(stat),
+		},
+	}
+}
+
+// elbv2Client adapts *elbv2.ELBV2 (plus the tagging API, to find the target group tagged for a
+// service) to the elbTargetHealthGetter interface.
+type elbv2Client struct {
+	client   *elbv2.ELBV2
+	rgClient *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
+}
+
+// TargetGroupARN returns the ARN of the target group tagged for the given service, or "" if the
+// service has no load balancer.
+func (c *elbv2Client) TargetGroupARN(app, env, svc string) (string, error) {
+	out, err := c.rgClient.GetResources(&resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice([]string{"elasticloadbalancing:targetgroup"}),
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{
+			{Key: aws.String("ecs-application"), Values: aws.StringSlice([]string{app})},
+			{Key: aws.String("ecs-environment"), Values: aws.StringSlice([]string{env})},
+			{Key: aws.String("ecs-service"), Values: aws.StringSlice([]string{svc})},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("find tagged target group for service %s: %w", svc, err)
+	}
+	if len(out.ResourceTagMappingList) == 0 {
+		return "", nil
+	}
+	return aws.StringValue(out.ResourceTagMappingList[0].ResourceARN), nil
+}
+
+// TargetHealth returns the health of every target registered to the given target group.
+func (c *elbv2Client) TargetHealth(targetGroupARN string) ([]TargetHealth, error) {
+	out, err := c.client.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(targetGroupARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe target health for %s: %w", targetGroupARN, err)
+	}
+	health := make([]TargetHealth, 0, len(out.TargetHealthDescriptions))
+	for _, d := range out.TargetHealthDescriptions {
+		health = append(health, TargetHealth{
+			ID:     aws.StringValue(d.Target.Id),
+			Port:   aws.Int64Value(d.Target.Port),
+			State:  aws.StringValue(d.TargetHealth.State),
+			Reason: aws.StringValue(d.TargetHealth.Reason),
+		})
+	}
+	return health, nil
+}
+
+// rdsClient adapts *rds.RDS to the addonHealthGetter interface.
+type rdsClient struct {
+	client *rds.RDS
+}
+
+// AddonHealth returns the status of the service's RDS/Aurora addon, or nil if it doesn't have one.
+func (c *rdsClient) AddonHealth(app, env, svc string) (*AddonStatus, error) {
+	identifier := fmt.Sprintf("%s-%s-%s", app, env, svc)
+	out, err := c.client.DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		if isDBInstanceNotFoundErr(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("describe db instance %s: %w", identifier, err)
+	}
+	if len(out.DBInstances) == 0 {
+		return nil, nil
+	}
+	db := out.DBInstances[0]
+	return &AddonStatus{
+		Identifier: aws.StringValue(db.DBInstanceIdentifier),
+		Engine:     aws.StringValue(db.Engine),
+		Status:     aws.StringValue(db.DBInstanceStatus),
+	}, nil
+}
+
+func isDBInstanceNotFoundErr(err error) bool {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return aerr.Code() == rds.ErrCodeDBInstanceNotFoundFault
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// ErrStackNotExists means the underlying CloudFormation stack for an application does not exist.
+var ErrStackNotExists = errors.New("stack does not exist")
+
+// IsStackNotExistsErr returns true if the error happened because the stack does not exist.
+func IsStackNotExistsErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrStackNotExists) {
+		return true
+	}
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		// CloudFormation returns a generic ValidationError for several unrelated failures
+		// (bad parameter, malformed name, ...), so also require the message to confirm this
+		// one really means the stack is missing, rather than misclassifying every validation
+		// failure as "not deployed".
+		return aerr.Code() == "ValidationError" && strings.Contains(aerr.Message(), "does not exist")
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+// Names of the built-in StatusSources, used to tell the required ECS source apart from the
+// optional ones when a source fails.
+const (
+	statusSourceECS               = "ecs"
+	statusSourceAlarms            = "alarms"
+	statusSourceLoadBalancer      = "load-balancer"
+	statusSourceContainerInsights = "container-insights"
+	statusSourceAddon             = "addon"
+)
+
+// containerInsightsWindow is how far back Container Insights CPU/memory percentiles are computed.
+const containerInsightsWindow = 1 * time.Hour
+
+// ErrSourceNotApplicable is returned by a StatusSource when the resource it describes (an ALB
+// target group, an RDS addon, ...) doesn't exist for this application, so its section of
+// AppStatus should simply be left empty rather than treated as a failure.
+var ErrSourceNotApplicable = errors.New("status source not applicable to this service")
+
+// LoadBalancerStatus is the health of the targets registered to a service's target group.
+type LoadBalancerStatus struct {
+	TargetGroupARN string         `json:"targetGroupARN"`
+	Targets        []TargetHealth `json:"targets"`
+}
+
+// TargetHealth is the health of a single ALB/NLB target.
+type TargetHealth struct {
+	ID     string `json:"id"`
+	Port   int64  `json:"port"`
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ContainerInsights holds CPU/memory percentiles over the last hour, as reported by CloudWatch
+// Container Insights.
+type ContainerInsights struct {
+	CPUP50    float64 `json:"cpuP50"`
+	CPUP95    float64 `json:"cpuP95"`
+	MemoryP50 float64 `json:"memoryP50"`
+	MemoryP95 float64 `json:"memoryP95"`
+}
+
+// AddonStatus is the health of a service's RDS/Aurora addon, if it has one.
+type AddonStatus struct {
+	Identifier string `json:"identifier"`
+	Engine     string `json:"engine"`
+	Status     string `json:"status"`
+}
+
+// SourceResult is the typed contribution a single StatusSource makes to an AppStatus. Only the
+// fields relevant to that source are populated; the rest are left as zero values and ignored
+// by AppStatus.merge.
+type SourceResult struct {
+	Service      *ecs.Service
+	Tasks        []ecs.Task
+	Alarms       []cloudwatch.MetricAlarm
+	LoadBalancer *LoadBalancerStatus
+	Insights     *ContainerInsights
+	Addon        *AddonStatus
+}
+
+// StatusSource describes one aspect of a deployed service's health (its ECS service, its
+// CloudWatch alarms, its load balancer targets, ...). StatusDescriber fans out to every
+// configured StatusSource and merges their SourceResults into a single AppStatus.
+type StatusSource interface {
+	// Name identifies the source for error reporting.
+	Name() string
+	// Describe returns this source's contribution to an AppStatus, or ErrSourceNotApplicable
+	// if the underlying resource doesn't exist for this service.
+	Describe(ctx context.Context) (SourceResult, error)
+}
+
+// defaultStatusSources returns the built-in StatusSource set: the ECS service itself, its
+// CloudWatch alarms, its ALB target group health, its Container Insights CPU/memory
+// percentiles, and its RDS/Aurora addon health, if any. Each source is backed by a thin
+// adapter (in clients.go) that calls the real SDK operations, since the SDK clients
+// themselves don't expose the shape these sources need.
+func defaultStatusSources(sess *session.Session, app, env, svc string) []StatusSource {
+	cluster := fmt.Sprintf("%s-%s", app, env)
+	rgClient := resourcegroupstaggingapi.New(sess)
+	cw := &cloudwatchClient{client: cloudwatch.New(sess), rgClient: rgClient}
+	return []StatusSource{
+		&ecsServiceSource{client: &ecsClient{client: ecs.New(sess)}, cluster: cluster, service: svc},
+		&alarmSource{client: cw, app: app, env: env, svc: svc},
+		&albTargetHealthSource{client: &elbv2Client{client: elbv2.New(sess), rgClient: rgClient}, app: app, env: env, svc: svc},
+		&containerInsightsSource{client: cw, cluster: cluster, service: svc},
+		&addonStatusSource{client: &rdsClient{client: rds.New(sess)}, app: app, env: env, svc: svc},
+	}
+}
+
+type ecsServiceGetter interface {
+	Service(clusterName, serviceName string) (*ecs.Service, error)
+	ServiceTasks(clusterName, serviceName string) ([]*ecs.Task, error)
+}
+
+// ecsServiceSource describes the ECS service backing a deployed copilot service and its tasks.
+// This is the only required source: if it fails, the whole describe fails.
+type ecsServiceSource struct {
+	client  ecsServiceGetter
+	cluster string
+	service string
+}
+
+func (s *ecsServiceSource) Name() string { return statusSourceECS }
+
+func (s *ecsServiceSource) Describe(ctx context.Context) (SourceResult, error) {
+	service, err := s.client.Service(s.cluster, s.service)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get service data for %s: %w", s.service, err)
+	}
+	tasks, err := s.client.ServiceTasks(s.cluster, s.service)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get tasks for service %s: %w", s.service, err)
+	}
+	res := SourceResult{Service: service}
+	for _, t := range tasks {
+		res.Tasks = append(res.Tasks, *t)
+	}
+	return res, nil
+}
+
+type alarmGetter interface {
+	AlarmsWithTags(tags map[string]string) ([]cloudwatch.MetricAlarm, error)
+}
+
+// alarmSource describes the CloudWatch alarms tagged for a deployed service.
+type alarmSource struct {
+	client        alarmGetter
+	app, env, svc string
+}
+
+func (s *alarmSource) Name() string { return statusSourceAlarms }
+
+func (s *alarmSource) Describe(ctx context.Context) (SourceResult, error) {
+	alarms, err := s.client.AlarmsWithTags(map[string]string{
+		"ecs-application": s.app,
+		"ecs-environment": s.env,
+		"ecs-service":     s.svc,
+	})
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get cloudwatch alarms for service %s: %w", s.svc, err)
+	}
+	return SourceResult{Alarms: alarms}, nil
+}
+
+type elbTargetHealthGetter interface {
+	TargetGroupARN(app, env, svc string) (string, error)
+	TargetHealth(targetGroupARN string) ([]TargetHealth, error)
+}
+
+// albTargetHealthSource describes the health of the targets registered to a service's ALB
+// target group, if it has one.
+type albTargetHealthSource struct {
+	client        elbTargetHealthGetter
+	app, env, svc string
+}
+
+func (s *albTargetHealthSource) Name() string { return statusSourceLoadBalancer }
+
+func (s *albTargetHealthSource) Describe(ctx context.Context) (SourceResult, error) {
+	arn, err := s.client.TargetGroupARN(s.app, s.env, s.svc)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get target group for service %s: %w", s.svc, err)
+	}
+	if arn == "" {
+		return SourceResult{}, ErrSourceNotApplicable
+	}
+	targets, err := s.client.TargetHealth(arn)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get target health for %s: %w", arn, err)
+	}
+	return SourceResult{LoadBalancer: &LoadBalancerStatus{TargetGroupARN: arn, Targets: targets}}, nil
+}
+
+type containerInsightsGetter interface {
+	ContainerInsights(cluster, service string, window time.Duration) (*ContainerInsights, error)
+}
+
+// containerInsightsSource describes CPU/memory percentiles over the last hour, as reported by
+// CloudWatch Container Insights.
+type containerInsightsSource struct {
+	client  containerInsightsGetter
+	cluster string
+	service string
+}
+
+func (s *containerInsightsSource) Name() string { return statusSourceContainerInsights }
+
+func (s *containerInsightsSource) Describe(ctx context.Context) (SourceResult, error) {
+	insights, err := s.client.ContainerInsights(s.cluster, s.service, containerInsightsWindow)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get container insights for service %s: %w", s.service, err)
+	}
+	if insights == nil {
+		return SourceResult{}, ErrSourceNotApplicable
+	}
+	return SourceResult{Insights: insights}, nil
+}
+
+type addonHealthGetter interface {
+	AddonHealth(app, env, svc string) (*AddonStatus, error)
+}
+
+// addonStatusSource describes the health of a service's RDS/Aurora addon, if it has one.
+type addonStatusSource struct {
+	client        addonHealthGetter
+	app, env, svc string
+}
+
+func (s *addonStatusSource) Name() string { return statusSourceAddon }
+
+func (s *addonStatusSource) Describe(ctx context.Context) (SourceResult, error) {
+	addon, err := s.client.AddonHealth(s.app, s.env, s.svc)
+	if err != nil {
+		return SourceResult{}, fmt.Errorf("get addon health for service %s: %w", s.svc, err)
+	}
+	if addon == nil {
+		return SourceResult{}, ErrSourceNotApplicable
+	}
+	return SourceResult{Addon: addon}, nil
+}
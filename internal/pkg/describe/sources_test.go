@@ -0,0 +1,118 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeECSServiceGetter struct {
+	service *ecs.Service
+	tasks   []*ecs.Task
+	err     error
+}
+
+func (f *fakeECSServiceGetter) Service(cluster, service string) (*ecs.Service, error) {
+	return f.service, f.err
+}
+
+func (f *fakeECSServiceGetter) ServiceTasks(cluster, service string) ([]*ecs.Task, error) {
+	return f.tasks, f.err
+}
+
+func TestECSServiceSource_Describe(t *testing.T) {
+	t.Run("a client error is wrapped", func(t *testing.T) {
+		s := &ecsServiceSource{client: &fakeECSServiceGetter{err: errors.New("some error")}, cluster: "c", service: "svc"}
+		_, err := s.Describe(context.Background())
+		require.EqualError(t, err, "get service data for svc: some error")
+	})
+	t.Run("tasks are dereferenced into the result", func(t *testing.T) {
+		s := &ecsServiceSource{client: &fakeECSServiceGetter{service: &ecs.Service{}, tasks: []*ecs.Task{{}, {}}}, cluster: "c", service: "svc"}
+		res, err := s.Describe(context.Background())
+		require.NoError(t, err)
+		require.Len(t, res.Tasks, 2)
+	})
+}
+
+type fakeElbTargetHealthGetter struct {
+	arn     string
+	targets []TargetHealth
+	arnErr  error
+	err     error
+}
+
+func (f *fakeElbTargetHealthGetter) TargetGroupARN(app, env, svc string) (string, error) {
+	return f.arn, f.arnErr
+}
+
+func (f *fakeElbTargetHealthGetter) TargetHealth(targetGroupARN string) ([]TargetHealth, error) {
+	return f.targets, f.err
+}
+
+func TestAlbTargetHealthSource_Describe(t *testing.T) {
+	t.Run("no target group is not applicable", func(t *testing.T) {
+		s := &albTargetHealthSource{client: &fakeElbTargetHealthGetter{arn: ""}}
+		_, err := s.Describe(context.Background())
+		require.True(t, errors.Is(err, ErrSourceNotApplicable))
+	})
+	t.Run("a target group is described", func(t *testing.T) {
+		s := &albTargetHealthSource{client: &fakeElbTargetHealthGetter{arn: "arn:tg", targets: []TargetHealth{{ID: "i-1", State: "healthy"}}}}
+		res, err := s.Describe(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "arn:tg", res.LoadBalancer.TargetGroupARN)
+		require.Len(t, res.LoadBalancer.Targets, 1)
+	})
+}
+
+type fakeContainerInsightsGetter struct {
+	insights *ContainerInsights
+	err      error
+}
+
+func (f *fakeContainerInsightsGetter) ContainerInsights(cluster, service string, window time.Duration) (*ContainerInsights, error) {
+	return f.insights, f.err
+}
+
+func TestContainerInsightsSource_Describe(t *testing.T) {
+	t.Run("container insights disabled is not applicable", func(t *testing.T) {
+		s := &containerInsightsSource{client: &fakeContainerInsightsGetter{insights: nil}}
+		_, err := s.Describe(context.Background())
+		require.True(t, errors.Is(err, ErrSourceNotApplicable))
+	})
+	t.Run("container insights are returned", func(t *testing.T) {
+		s := &containerInsightsSource{client: &fakeContainerInsightsGetter{insights: &ContainerInsights{CPUP50: 1.5}}}
+		res, err := s.Describe(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1.5, res.Insights.CPUP50)
+	})
+}
+
+type fakeAddonHealthGetter struct {
+	addon *AddonStatus
+	err   error
+}
+
+func (f *fakeAddonHealthGetter) AddonHealth(app, env, svc string) (*AddonStatus, error) {
+	return f.addon, f.err
+}
+
+func TestAddonStatusSource_Describe(t *testing.T) {
+	t.Run("no addon is not applicable", func(t *testing.T) {
+		s := &addonStatusSource{client: &fakeAddonHealthGetter{addon: nil}}
+		_, err := s.Describe(context.Background())
+		require.True(t, errors.Is(err, ErrSourceNotApplicable))
+	})
+	t.Run("an addon is described", func(t *testing.T) {
+		s := &addonStatusSource{client: &fakeAddonHealthGetter{addon: &AddonStatus{Identifier: "db-1"}}}
+		res, err := s.Describe(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "db-1", res.Addon.Identifier)
+	})
+}
@@ -0,0 +1,300 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package describe provides functionality to describe applications, services and environments.
+package describe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/describe/status"
+	"github.com/aws/amazon-ecs-cli-v2/internal/pkg/term/log"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ecs"
+)
+
+// defaultStreamInterval is how often StreamStatus polls for changes when the caller
+// does not override it.
+const defaultStreamInterval = 10 * time.Second
+
+// AppStatus contains the status for a deployed application, assembled from every
+// configured StatusSource.
+type AppStatus struct {
+	App          string                   `json:"app"`
+	Env          string                   `json:"env"`
+	Svc          string                   `json:"svc"`
+	Service      ecs.Service              `json:"service"`
+	Tasks        []ecs.Task               `json:"tasks"`
+	Alarms       []cloudwatch.MetricAlarm `json:"alarms"`
+	LoadBalancer *LoadBalancerStatus      `json:"loadBalancer,omitempty"`
+	Insights     *ContainerInsights       `json:"insights,omitempty"`
+	Addon        *AddonStatus             `json:"addon,omitempty"`
+}
+
+// merge folds a single source's contribution into the aggregate status. A zero-value field on
+// r leaves the corresponding AppStatus field untouched.
+func (s *AppStatus) merge(r SourceResult) {
+	if r.Service != nil {
+		s.Service = *r.Service
+	}
+	if r.Tasks != nil {
+		s.Tasks = r.Tasks
+	}
+	if r.Alarms != nil {
+		s.Alarms = r.Alarms
+	}
+	if r.LoadBalancer != nil {
+		s.LoadBalancer = r.LoadBalancer
+	}
+	if r.Insights != nil {
+		s.Insights = r.Insights
+	}
+	if r.Addon != nil {
+		s.Addon = r.Addon
+	}
+}
+
+// StatusDescriber retrieves the status of an application by fanning out to every configured
+// StatusSource and merging their contributions into a single AppStatus.
+type StatusDescriber struct {
+	app string
+	env string
+	svc string
+
+	sources []StatusSource
+}
+
+// NewAppStatus instantiates a new StatusDescriber struct wired to the default set of status
+// sources: the ECS service, its CloudWatch alarms, its ALB target group health, Container
+// Insights, and its RDS/Aurora addon, if any.
+func NewAppStatus(app, env, svc string) (*StatusDescriber, error) {
+	sess, err := session.NewShared()
+	if err != nil {
+		return nil, fmt.Errorf("create new session: %w", err)
+	}
+	return &StatusDescriber{
+		app:     app,
+		env:     env,
+		svc:     svc,
+		sources: defaultStatusSources(sess, app, env, svc),
+	}, nil
+}
+
+// Describe returns the status of an application by querying every configured StatusSource
+// concurrently. A failure in the ECS source aborts the describe; failures in the other,
+// optional sources are logged internally and simply leave their AppStatus section empty.
+func (s *StatusDescriber) Describe() (*AppStatus, error) {
+	return s.describe(context.Background())
+}
+
+// DescribeContext behaves like Describe, except the in-flight AWS calls are abandoned as soon
+// as ctx is cancelled, instead of always running to completion.
+func (s *StatusDescriber) DescribeContext(ctx context.Context) (*AppStatus, error) {
+	return s.describe(ctx)
+}
+
+func (s *StatusDescriber) describe(ctx context.Context) (*AppStatus, error) {
+	type sourceResult struct {
+		name string
+		res  SourceResult
+		err  error
+	}
+	resCh := make(chan sourceResult, len(s.sources))
+	for _, src := range s.sources {
+		src := src
+		go func() {
+			res, err := src.Describe(ctx)
+			resCh <- sourceResult{name: src.Name(), res: res, err: err}
+		}()
+	}
+
+	out := &AppStatus{App: s.app, Env: s.env, Svc: s.svc}
+	for range s.sources {
+		var r sourceResult
+		select {
+		case r = <-resCh:
+		case <-ctx.Done():
+			// Abandon waiting on the remaining in-flight sources; their goroutines still
+			// finish in the background since the underlying SDK calls aren't themselves
+			// context-aware, but the caller is freed to move on immediately.
+			return nil, ctx.Err()
+		}
+		switch {
+		case r.err == nil:
+			out.merge(r.res)
+		case errors.Is(r.err, ErrSourceNotApplicable):
+			// This source doesn't apply to this service (e.g. no load balancer, no addon).
+		case r.name == statusSourceECS:
+			return nil, fmt.Errorf("describe %s status: %w", r.name, r.err)
+		default:
+			// Optional sources degrade gracefully: the corresponding AppStatus section is
+			// simply left empty rather than failing the whole describe, but the failure
+			// itself is still logged so an operator can tell why a section is missing.
+			log.Errorf("describe %s status for %s: %v\n", r.name, s.svc, r.err)
+		}
+	}
+	return out, nil
+}
+
+// StreamStatus polls Describe at the given interval and sends a new AppStatus on the
+// returned channel only when it differs from the previously emitted snapshot. The channel
+// is closed and polling stops once ctx is cancelled.
+func (s *StatusDescriber) StreamStatus(ctx context.Context, interval time.Duration) (<-chan AppStatus, error) {
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	}
+	events := make(chan AppStatus)
+	go func() {
+		defer close(events)
+		var prev *AppStatus
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			status, err := s.describe(ctx)
+			if err == nil && (prev == nil || !reflect.DeepEqual(*prev, *status)) {
+				prev = status
+				select {
+				case events <- *status:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events, nil
+}
+
+// HumanString returns the stringified AppStatus struct with human readable format.
+func (s *AppStatus) HumanString() string {
+	out := fmt.Sprintf("service: %s\ntasks: %d\nalarms: %d\n", aws.StringValue(s.Service.ServiceName), len(s.Tasks), len(s.Alarms))
+	if s.LoadBalancer != nil {
+		out += "\nLoad Balancer Targets\n"
+		for _, t := range s.LoadBalancer.Targets {
+			out += fmt.Sprintf("  %s:%d %s (%s)\n", t.ID, t.Port, t.State, t.Reason)
+		}
+	}
+	if s.Insights != nil {
+		out += fmt.Sprintf("\nContainer Insights (last 1h)\n  cpu:    p50=%.1f%% p95=%.1f%%\n  memory: p50=%.1f%% p95=%.1f%%\n",
+			s.Insights.CPUP50, s.Insights.CPUP95, s.Insights.MemoryP50, s.Insights.MemoryP95)
+	}
+	return out
+}
+
+// JSONString returns the stringified AppStatus struct with json format.
+//
+// Deprecated: use status.New(status.JSON, "").Render instead.
+func (s *AppStatus) JSONString() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("marshal application status: %w", err)
+	}
+	return fmt.Sprintf("%s\n", b), nil
+}
+
+// Data returns the AppStatus itself so it can be marshaled by the non-human status.Renderer implementations.
+func (s *AppStatus) Data() interface{} {
+	return s
+}
+
+// TSVRows implements status.TSVModel so AppStatus can be rendered with --format tsv.
+func (s *AppStatus) TSVRows() [][]string {
+	rows := [][]string{{"TASK", "LAST STATUS", "HEALTH STATUS"}}
+	for _, t := range s.Tasks {
+		rows = append(rows, []string{aws.StringValue(t.TaskArn), aws.StringValue(t.LastStatus), aws.StringValue(t.HealthStatus)})
+	}
+	return rows
+}
+
+// HealthState is the overall health of a described application status.
+type HealthState string
+
+// Possible HealthState values, ordered from best to worst.
+const (
+	HealthStateHealthy  HealthState = "healthy"
+	HealthStateDegraded HealthState = "degraded"
+	HealthStateFailed   HealthState = "failed"
+)
+
+// Health classifies the status as failed (an alarm is in ALARM or a task stopped), degraded
+// (an alarm is in INSUFFICIENT_DATA or the running task count hasn't caught up to desired,
+// e.g. mid-rolling-deploy), or healthy otherwise.
+func (s *AppStatus) Health() HealthState {
+	for _, a := range s.Alarms {
+		if aws.StringValue(a.StateValue) == "ALARM" {
+			return HealthStateFailed
+		}
+	}
+	for _, t := range s.Tasks {
+		if aws.StringValue(t.LastStatus) == "STOPPED" {
+			return HealthStateFailed
+		}
+	}
+	degraded := aws.Int64Value(s.Service.RunningCount) < aws.Int64Value(s.Service.DesiredCount)
+	for _, a := range s.Alarms {
+		if aws.StringValue(a.StateValue) == "INSUFFICIENT_DATA" {
+			degraded = true
+		}
+	}
+	if degraded {
+		return HealthStateDegraded
+	}
+	return HealthStateHealthy
+}
+
+// PrometheusMetrics implements status.PrometheusModel so AppStatus can be scraped with
+// --format prometheus.
+func (s *AppStatus) PrometheusMetrics() []status.PrometheusMetric {
+	labels := map[string]string{"app": s.App, "env": s.Env, "svc": s.Svc}
+	metrics := []status.PrometheusMetric{
+		{
+			Name:   "copilot_service_running_tasks",
+			Help:   "Number of running tasks for the service.",
+			Labels: labels,
+			Value:  float64(aws.Int64Value(s.Service.RunningCount)),
+		},
+		{
+			Name:   "copilot_service_desired_tasks",
+			Help:   "Number of desired tasks for the service.",
+			Labels: labels,
+			Value:  float64(aws.Int64Value(s.Service.DesiredCount)),
+		},
+	}
+	for _, t := range s.Tasks {
+		taskLabels := map[string]string{"app": s.App, "env": s.Env, "svc": s.Svc, "task": aws.StringValue(t.TaskArn), "status": aws.StringValue(t.LastStatus)}
+		value := 0.0
+		if aws.StringValue(t.HealthStatus) == "HEALTHY" {
+			value = 1
+		}
+		metrics = append(metrics, status.PrometheusMetric{
+			Name:   "copilot_task_health",
+			Help:   "Whether the task's health check is currently healthy (1) or not (0).",
+			Labels: taskLabels,
+			Value:  value,
+		})
+	}
+	for _, a := range s.Alarms {
+		metrics = append(metrics, status.PrometheusMetric{
+			Name: "copilot_alarm_state",
+			Help: "CloudWatch alarm state: OK, ALARM, or INSUFFICIENT_DATA.",
+			Labels: map[string]string{
+				"app": s.App, "env": s.Env, "svc": s.Svc,
+				"alarm": aws.StringValue(a.AlarmName),
+				"state": aws.StringValue(a.StateValue),
+			},
+			Value: 1,
+		})
+	}
+	return metrics
+}
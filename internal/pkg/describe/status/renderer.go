@@ -0,0 +1,185 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package status renders described resources (applications, services, environments) in a
+// variety of output formats shared across the describe CLI commands. New read commands
+// should implement Model (and, where it makes sense, TSVModel/PrometheusModel) on their
+// describe result and call New to get a Renderer, the same way app status does, rather than
+// formatting output themselves.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Supported output formats.
+const (
+	Human      = "human"
+	JSON       = "json"
+	YAML       = "yaml"
+	TSV        = "tsv"
+	Template   = "template"
+	Prometheus = "prometheus"
+)
+
+// PrometheusMetric describes a single OpenMetrics/Prometheus gauge sample.
+type PrometheusMetric struct {
+	Name   string
+	Help   string
+	Labels map[string]string
+	Value  float64
+}
+
+// PrometheusModel is optionally implemented by Models that can be exported as
+// Prometheus/OpenMetrics metrics.
+type PrometheusModel interface {
+	Model
+	// PrometheusMetrics returns the metric samples to expose for this resource.
+	PrometheusMetrics() []PrometheusMetric
+}
+
+// Model is implemented by describe types that can be rendered through the status renderer set.
+type Model interface {
+	// HumanString returns a human-friendly, already formatted representation.
+	HumanString() string
+	// Data returns the underlying value to marshal for the non-human formats.
+	Data() interface{}
+}
+
+// TSVModel is optionally implemented by Models that support tab-separated output.
+type TSVModel interface {
+	Model
+	// TSVRows returns a header row followed by one row per record.
+	TSVRows() [][]string
+}
+
+// Renderer writes a Model to w in a particular format.
+type Renderer interface {
+	Render(w io.Writer, m Model) error
+}
+
+// New returns the Renderer for the given format. tmpl is only used when format is Template.
+func New(format, tmpl string) (Renderer, error) {
+	switch format {
+	case "", Human:
+		return humanRenderer{}, nil
+	case JSON:
+		return jsonRenderer{}, nil
+	case YAML:
+		return yamlRenderer{}, nil
+	case TSV:
+		return tsvRenderer{}, nil
+	case Template:
+		if tmpl == "" {
+			return nil, fmt.Errorf("--template is required when --format=%s", Template)
+		}
+		t, err := template.New("status").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("parse template: %w", err)
+		}
+		return templateRenderer{t: t}, nil
+	case Prometheus:
+		return prometheusRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized format %q", format)
+	}
+}
+
+type humanRenderer struct{}
+
+func (humanRenderer) Render(w io.Writer, m Model) error {
+	_, err := fmt.Fprint(w, m.HumanString())
+	return err
+}
+
+type jsonRenderer struct{}
+
+func (jsonRenderer) Render(w io.Writer, m Model) error {
+	b, err := json.Marshal(m.Data())
+	if err != nil {
+		return fmt.Errorf("marshal to json: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+type yamlRenderer struct{}
+
+func (yamlRenderer) Render(w io.Writer, m Model) error {
+	b, err := yaml.Marshal(m.Data())
+	if err != nil {
+		return fmt.Errorf("marshal to yaml: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "%s", b)
+	return err
+}
+
+type tsvRenderer struct{}
+
+func (tsvRenderer) Render(w io.Writer, m Model) error {
+	tm, ok := m.(TSVModel)
+	if !ok {
+		return fmt.Errorf("--format=%s is not supported for this resource", TSV)
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, row := range tm.TSVRows() {
+		for i, col := range row {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprint(tw, col)
+		}
+		fmt.Fprint(tw, "\n")
+	}
+	return tw.Flush()
+}
+
+type templateRenderer struct {
+	t *template.Template
+}
+
+func (r templateRenderer) Render(w io.Writer, m Model) error {
+	return r.t.Execute(w, m.Data())
+}
+
+type prometheusRenderer struct{}
+
+// Render writes m's metrics as OpenMetrics/Prometheus exposition text, emitting a single
+// HELP/TYPE preamble per metric name followed by its samples.
+func (prometheusRenderer) Render(w io.Writer, m Model) error {
+	pm, ok := m.(PrometheusModel)
+	if !ok {
+		return fmt.Errorf("--format=%s is not supported for this resource", Prometheus)
+	}
+	seen := make(map[string]bool)
+	for _, metric := range pm.PrometheusMetrics() {
+		if !seen[metric.Name] {
+			seen[metric.Name] = true
+			fmt.Fprintf(w, "# HELP %s %s\n", metric.Name, metric.Help)
+			fmt.Fprintf(w, "# TYPE %s gauge\n", metric.Name)
+		}
+		fmt.Fprintf(w, "%s{%s} %v\n", metric.Name, formatPrometheusLabels(metric.Labels), metric.Value)
+	}
+	return nil
+}
+
+func formatPrometheusLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return strings.Join(pairs, ",")
+}
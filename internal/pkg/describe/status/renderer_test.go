@@ -0,0 +1,108 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata/")
+
+// fakeStatus is a stand-in for describe.AppStatus: just enough of a Model to exercise every
+// Renderer implementation.
+type fakeStatus struct {
+	Svc   string `json:"svc"`
+	Tasks int    `json:"tasks"`
+}
+
+func (f fakeStatus) HumanString() string {
+	return "svc: " + f.Svc + "\ntasks: 2\n"
+}
+
+func (f fakeStatus) Data() interface{} {
+	return f
+}
+
+func (f fakeStatus) TSVRows() [][]string {
+	return [][]string{
+		{"TASK", "STATUS"},
+		{"task-1", "RUNNING"},
+		{"task-2", "RUNNING"},
+	}
+}
+
+func (f fakeStatus) PrometheusMetrics() []PrometheusMetric {
+	return []PrometheusMetric{
+		{
+			Name:   "copilot_service_running_tasks",
+			Help:   "Number of running tasks for the service.",
+			Labels: map[string]string{"svc": f.Svc},
+			Value:  float64(f.Tasks),
+		},
+	}
+}
+
+func TestRenderer_Golden(t *testing.T) {
+	m := fakeStatus{Svc: "api", Tasks: 2}
+	testCases := map[string]struct {
+		format string
+		tmpl   string
+	}{
+		"human":      {format: Human},
+		"json":       {format: JSON},
+		"yaml":       {format: YAML},
+		"tsv":        {format: TSV},
+		"template":   {format: Template, tmpl: "{{.Svc}} has {{.Tasks}} tasks\n"},
+		"prometheus": {format: Prometheus},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			renderer, err := New(tc.format, tc.tmpl)
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, renderer.Render(&buf, m))
+
+			golden := filepath.Join("testdata", name+".golden")
+			if *update {
+				require.NoError(t, ioutil.WriteFile(golden, buf.Bytes(), 0644))
+			}
+			wanted, err := ioutil.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(wanted), buf.String())
+		})
+	}
+}
+
+func TestRenderer_UnsupportedFormat(t *testing.T) {
+	_, err := New("bogus", "")
+	require.EqualError(t, err, `unrecognized format "bogus"`)
+}
+
+func TestRenderer_TemplateRequiresFlag(t *testing.T) {
+	_, err := New(Template, "")
+	require.EqualError(t, err, "--template is required when --format=template")
+}
+
+func TestRenderer_TSVNotSupported(t *testing.T) {
+	renderer, err := New(TSV, "")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = renderer.Render(&buf, humanOnlyModel{})
+	require.EqualError(t, err, "--format=tsv is not supported for this resource")
+}
+
+// humanOnlyModel implements Model but neither TSVModel nor PrometheusModel; it is used to
+// exercise the TSV renderer's "not supported for this resource" branch.
+type humanOnlyModel struct{}
+
+func (humanOnlyModel) HumanString() string { return "" }
+func (humanOnlyModel) Data() interface{}   { return nil }
@@ -0,0 +1,150 @@
+// Copyright 2019 Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package describe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppStatus_Merge(t *testing.T) {
+	lb := &LoadBalancerStatus{TargetGroupARN: "arn:lb"}
+	insights := &ContainerInsights{CPUP50: 1}
+	addon := &AddonStatus{Identifier: "db"}
+
+	testCases := map[string]struct {
+		initial AppStatus
+		result  SourceResult
+		want    AppStatus
+	}{
+		"zero-value fields on the result leave the aggregate untouched": {
+			initial: AppStatus{App: "my-app", LoadBalancer: lb},
+			result:  SourceResult{},
+			want:    AppStatus{App: "my-app", LoadBalancer: lb},
+		},
+		"populated fields overwrite the corresponding section": {
+			initial: AppStatus{},
+			result: SourceResult{
+				Service:      &ecs.Service{ServiceName: aws.String("svc")},
+				Tasks:        []ecs.Task{{TaskArn: aws.String("task-1")}},
+				Alarms:       []cloudwatch.MetricAlarm{{AlarmName: aws.String("alarm-1")}},
+				LoadBalancer: lb,
+				Insights:     insights,
+				Addon:        addon,
+			},
+			want: AppStatus{
+				Service:      ecs.Service{ServiceName: aws.String("svc")},
+				Tasks:        []ecs.Task{{TaskArn: aws.String("task-1")}},
+				Alarms:       []cloudwatch.MetricAlarm{{AlarmName: aws.String("alarm-1")}},
+				LoadBalancer: lb,
+				Insights:     insights,
+				Addon:        addon,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.initial
+			got.merge(tc.result)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAppStatus_Health(t *testing.T) {
+	testCases := map[string]struct {
+		status AppStatus
+		want   HealthState
+	}{
+		"an ALARM alarm is failed": {
+			status: AppStatus{Alarms: []cloudwatch.MetricAlarm{{StateValue: aws.String("ALARM")}}},
+			want:   HealthStateFailed,
+		},
+		"a stopped task is failed": {
+			status: AppStatus{Tasks: []ecs.Task{{LastStatus: aws.String("STOPPED")}}},
+			want:   HealthStateFailed,
+		},
+		"running count below desired is degraded, not failed": {
+			status: AppStatus{Service: ecs.Service{RunningCount: aws.Int64(1), DesiredCount: aws.Int64(2)}},
+			want:   HealthStateDegraded,
+		},
+		"an INSUFFICIENT_DATA alarm is degraded": {
+			status: AppStatus{Alarms: []cloudwatch.MetricAlarm{{StateValue: aws.String("INSUFFICIENT_DATA")}}},
+			want:   HealthStateDegraded,
+		},
+		"no alarms, no stopped tasks, running meets desired: healthy": {
+			status: AppStatus{Service: ecs.Service{RunningCount: aws.Int64(2), DesiredCount: aws.Int64(2)}},
+			want:   HealthStateHealthy,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.status.Health())
+		})
+	}
+}
+
+// fakeSource is a StatusSource stub used to exercise StatusDescriber.describe's fan-out/merge.
+type fakeSource struct {
+	name string
+	res  SourceResult
+	err  error
+}
+
+func (f *fakeSource) Name() string { return f.name }
+func (f *fakeSource) Describe(ctx context.Context) (SourceResult, error) {
+	return f.res, f.err
+}
+
+func TestStatusDescriber_Describe(t *testing.T) {
+	testCases := map[string]struct {
+		sources []StatusSource
+		wantErr string
+		check   func(t *testing.T, status *AppStatus)
+	}{
+		"a failure in the required ECS source aborts the describe": {
+			sources: []StatusSource{
+				&fakeSource{name: statusSourceECS, err: errors.New("some error")},
+			},
+			wantErr: "describe ecs status: some error",
+		},
+		"ErrSourceNotApplicable from an optional source leaves its section empty": {
+			sources: []StatusSource{
+				&fakeSource{name: statusSourceECS, res: SourceResult{Service: &ecs.Service{ServiceName: aws.String("svc")}}},
+				&fakeSource{name: statusSourceLoadBalancer, err: ErrSourceNotApplicable},
+			},
+			check: func(t *testing.T, status *AppStatus) {
+				require.Nil(t, status.LoadBalancer)
+			},
+		},
+		"a failure in an optional source degrades gracefully instead of aborting": {
+			sources: []StatusSource{
+				&fakeSource{name: statusSourceECS, res: SourceResult{Service: &ecs.Service{ServiceName: aws.String("svc")}}},
+				&fakeSource{name: statusSourceContainerInsights, err: errors.New("some error")},
+			},
+			check: func(t *testing.T, status *AppStatus) {
+				require.Nil(t, status.Insights)
+				require.Equal(t, "svc", aws.StringValue(status.Service.ServiceName))
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := &StatusDescriber{app: "my-app", env: "test", svc: "api", sources: tc.sources}
+			status, err := d.describe(context.Background())
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, status)
+		})
+	}
+}